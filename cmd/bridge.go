@@ -0,0 +1,50 @@
+package main
+
+// Bridge - принимает поток каналов chanStream и разворачивает его в единый
+// выходной канал: для каждого полученного канала stream он полностью
+// вычитывается (с учетом done), после чего обработка переходит к
+// следующему каналу из chanStream. Выходной канал закрывается, когда
+// закрывается chanStream, либо раньше, если сработал done.
+func Bridge[T any](done <-chan struct{}, chanStream <-chan <-chan T) <-chan T {
+    output := make(chan T) // Канал для передачи значений из вложенных каналов
+
+    go func() {
+        defer close(output)
+
+        for {
+            var stream <-chan T
+            select {
+            case s, ok := <-chanStream: // Чтение очередного канала из потока каналов
+                if !ok {
+
+                    return
+                }
+                stream = s
+            case <-done: // Если пришел сигнал завершения работы
+
+                return
+            }
+
+        drain:
+            for {
+                select {
+                case data, ok := <-stream: // Чтение данных из текущего вложенного канала
+                    if !ok {
+                        break drain // Канал исчерпан, переходим к следующему из chanStream
+                    }
+                    select {
+                    case output <- data: // Отправка значения в общий выходной канал
+                    case <-done: // Если пришел сигнал завершения работы
+
+                        return
+                    }
+                case <-done: // Если пришел сигнал завершения работы
+
+                    return
+                }
+            }
+        }
+    }()
+
+    return output
+}
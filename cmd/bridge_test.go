@@ -0,0 +1,76 @@
+package main
+
+import (
+    "reflect"
+    "testing"
+    "time"
+)
+
+// TestBridgeFlattensStreamOfStreamsInOrder проверяет, что Bridge полностью
+// вычитывает каждый канал из chanStream перед тем, как перейти к
+// следующему, и закрывает выходной канал, когда chanStream закрывается.
+func TestBridgeFlattensStreamOfStreamsInOrder(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    chanStream := make(chan (<-chan int))
+    go func() {
+        defer close(chanStream)
+        chanStream <- Generator(done, 1, 2)
+        chanStream <- Generator(done, 3, 4)
+    }()
+
+    got := drain(Bridge(done, chanStream))
+    want := []int{1, 2, 3, 4}
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("Bridge() = %v, хотим %v", got, want)
+    }
+}
+
+// TestBridgeStopsOnDone проверяет, что Bridge закрывает выходной канал
+// сразу после done, не дожидаясь исчерпания текущего вложенного канала.
+func TestBridgeStopsOnDone(t *testing.T) {
+    done := make(chan struct{})
+    blocked := make(chan int) // Никогда не отправляет и не закрывается сам
+
+    chanStream := make(chan (<-chan int), 1)
+    chanStream <- blocked
+
+    output := Bridge(done, chanStream)
+    close(done)
+
+    select {
+    case _, ok := <-output:
+        if ok {
+            t.Fatal("ожидали закрытый канал после done, получили значение")
+        }
+    case <-time.After(time.Second):
+        t.Fatal("Bridge не закрыл выходной канал после done")
+    }
+}
+
+// TestPipelineRunDynamicFlattensAndTransforms проверяет, что RunDynamic
+// прогоняет данные из потока источников через Bridge и дальше через стадии
+// конвейера, позволяя на лету подменять источник без пересоздания стадий.
+func TestPipelineRunDynamicFlattensAndTransforms(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    double := func(in <-chan int, done <-chan struct{}) <-chan int {
+        return Map(done, in, func(i int) int { return i * 2 })
+    }
+    pipeline := NewPipeline[int](done, double)
+
+    sources := make(chan (<-chan int))
+    go func() {
+        defer close(sources)
+        sources <- Generator(done, 1, 2) // Например, это мог быть stdin
+        sources <- Generator(done, 3) // А это - переключение на TCP-источник
+    }()
+
+    got := drain(pipeline.RunDynamic(sources))
+    want := []int{2, 4, 6}
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("Pipeline.RunDynamic() = %v, хотим %v", got, want)
+    }
+}
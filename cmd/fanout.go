@@ -0,0 +1,100 @@
+package main
+
+import "sync"
+
+// FanOut - запускает n независимых потребителей общего входного канала in,
+// каждый из которых получает собственный выходной канал. Поскольку все они
+// читают из одного in, значения распределяются между ними, а не дублируются.
+func FanOut[T any](done <-chan struct{}, in <-chan T, n int) []<-chan T {
+    outputs := make([]<-chan T, n)
+    for i := 0; i < n; i++ {
+        outputs[i] = fanOutWorker(done, in)
+    }
+
+    return outputs
+}
+
+// fanOutWorker - одна ветка FanOut: пересылает значения из in в свой output,
+// пока in не закроется или не сработает done.
+func fanOutWorker[T any](done <-chan struct{}, in <-chan T) <-chan T {
+    output := make(chan T) // Канал для передачи значений этой ветки
+
+    go func() {
+        defer close(output)
+        for {
+            select {
+            case data, ok := <-in: // Чтение данных из общего входного канала
+                if !ok {
+
+                    return
+                }
+                select {
+                case output <- data: // Отправка значения в собственный выходной канал
+                case <-done: // Если пришел сигнал завершения работы
+
+                    return
+                }
+            case <-done: // Если пришел сигнал завершения работы
+
+                return
+            }
+        }
+    }()
+
+    return output
+}
+
+// FanIn - сливает несколько входных каналов chans в один выходной канал.
+// Выходной канал закрывается только после того, как все chans опустошены
+// и их горутины-потребители завершились, либо раньше, если сработал done.
+func FanIn[T any](done <-chan struct{}, chans ...<-chan T) <-chan T {
+    output := make(chan T) // Канал для передачи слитых значений
+    var wg sync.WaitGroup
+    wg.Add(len(chans))
+
+    for _, c := range chans {
+        go func(c <-chan T) {
+            defer wg.Done()
+            for {
+                select {
+                case data, ok := <-c: // Чтение данных из одного из входных каналов
+                    if !ok {
+
+                        return
+                    }
+                    select {
+                    case output <- data: // Отправка значения в общий выходной канал
+                    case <-done: // Если пришел сигнал завершения работы
+
+                        return
+                    }
+                case <-done: // Если пришел сигнал завершения работы
+
+                    return
+                }
+            }
+        }(c)
+    }
+
+    go func() {
+        wg.Wait() // Ждем, пока все входные каналы не будут вычитаны
+        close(output)
+    }()
+
+    return output
+}
+
+// Parallel - оборачивает stage так, что n горутин независимо обрабатывают
+// общий входной канал, а их результаты сливаются в один выходной канал через
+// FanIn. Используется, например, чтобы распределить CPU-зависимую стадию по
+// всем ядрам, сохраняя при этом прежний контракт Stage.
+func Parallel[T any](stage Stage[T], n int) Stage[T] {
+    return func(input <-chan T, done <-chan struct{}) <-chan T {
+        outputs := make([]<-chan T, n)
+        for i := 0; i < n; i++ {
+            outputs[i] = stage(input, done) // Каждый воркер читает из общего input
+        }
+
+        return FanIn(done, outputs...)
+    }
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+    "sort"
+    "sync"
+    "testing"
+    "time"
+)
+
+// TestFanOutDistributesWithoutDuplication проверяет, что значения из in
+// распределяются между ветками FanOut, а не дублируются в каждую из них.
+func TestFanOutDistributesWithoutDuplication(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    in := Generator(done, 1, 2, 3, 4, 5, 6)
+    outputs := FanOut(done, in, 3)
+
+    var wg sync.WaitGroup
+    var mu sync.Mutex
+    var got []int
+    for _, out := range outputs {
+        wg.Add(1)
+        go func(out <-chan int) {
+            defer wg.Done()
+            for v := range out {
+                mu.Lock()
+                got = append(got, v)
+                mu.Unlock()
+            }
+        }(out)
+    }
+    wg.Wait()
+
+    sort.Ints(got)
+    want := []int{1, 2, 3, 4, 5, 6}
+    if len(got) != len(want) {
+        t.Fatalf("FanOut суммарно выдал %v, хотим %v", got, want)
+    }
+    for i := range want {
+        if got[i] != want[i] {
+            t.Fatalf("FanOut суммарно выдал %v, хотим %v", got, want)
+        }
+    }
+}
+
+// TestFanInMergesAllChannels проверяет, что FanIn сливает значения из всех
+// входных каналов в один выходной, не теряя и не дублируя их.
+func TestFanInMergesAllChannels(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    c1 := Generator(done, 1, 2)
+    c2 := Generator(done, 3, 4)
+
+    got := drain(FanIn(done, c1, c2))
+    sort.Ints(got)
+    want := []int{1, 2, 3, 4}
+    if len(got) != len(want) {
+        t.Fatalf("FanIn() = %v, хотим %v", got, want)
+    }
+    for i := range want {
+        if got[i] != want[i] {
+            t.Fatalf("FanIn() = %v, хотим %v", got, want)
+        }
+    }
+}
+
+// TestFanInClosesOnDone проверяет, что FanIn закрывает выходной канал сразу
+// после done, не дожидаясь исчерпания входных каналов.
+func TestFanInClosesOnDone(t *testing.T) {
+    done := make(chan struct{})
+    blocked := make(chan int) // Никогда не отправляет и не закрывается сам
+
+    output := FanIn(done, blocked)
+    close(done)
+
+    select {
+    case _, ok := <-output:
+        if ok {
+            t.Fatal("ожидали закрытый канал после done, получили значение")
+        }
+    case <-time.After(time.Second):
+        t.Fatal("FanIn не закрыл выходной канал после done")
+    }
+}
+
+// TestParallelAppliesStageToAllInput проверяет, что Parallel сохраняет
+// контракт исходной стадии, распределяя вход между n горутинами и сливая
+// их результаты через FanIn.
+func TestParallelAppliesStageToAllInput(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    evenFilter := func(in <-chan int, done <-chan struct{}) <-chan int {
+        return Filter(done, in, func(i int) bool { return i%2 == 0 })
+    }
+
+    in := Generator(done, 1, 2, 3, 4, 5, 6)
+    got := drain(Parallel(evenFilter, 3)(in, done))
+    sort.Ints(got)
+    want := []int{2, 4, 6}
+    if len(got) != len(want) {
+        t.Fatalf("Parallel(evenFilter, 3)() = %v, хотим %v", got, want)
+    }
+    for i := range want {
+        if got[i] != want[i] {
+            t.Fatalf("Parallel(evenFilter, 3)() = %v, хотим %v", got, want)
+        }
+    }
+}
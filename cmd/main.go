@@ -2,11 +2,16 @@ package main
 
 import (
 	"bufio"
+	"flag"
+	"io"
 	"log"
 	"os"
+	"os/signal"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 )
 
@@ -15,85 +20,81 @@ const (
     bufferSize = 10 // Размер кольцевого буфера
 )
 
-// Структура RingIntBuffer - кольцевой буфер целых чисел
-type RingIntBuffer struct {
-    array []int // Массив для хранения элементов буфера
-    pos   int // Текущая позиция для записи в буфер
-    size  int // Размер буфера
-    mu     sync.Mutex // Мьютекс для обеспечения потокобезопасности
-}
-
-// Функция NewRingIntBuffer - создание нового буфера целых чисел
-func NewRingIntBuffer(size int) *RingIntBuffer {
-    return &RingIntBuffer {
-        array: make([]int, size),
-        pos: -1,
-        size: size,
-    }
-}
-
-// Метод Push - добавление нового элемента в буфер
-func (r *RingIntBuffer) Push(el int) {
-    r.mu.Lock()
-    defer r.mu.Unlock()
-
-    r.pos = (r.pos + 1) % r.size // Вычисление новой позиции для записи с учетом размера буфера
-    r.array[r.pos] = el // Запись элемента в буфер
-}
-
-// Метод Get - получение всех элементов буфера и его последующая очистка
-func (r *RingIntBuffer) Get() []int {
-    // Проверка, если буфер пуст (позиция меньше 0), возвращаем nil
-    if r.pos < 0 {
-        return nil
+func main() {
+    var (
+        sourceFlag = flag.String("source", "stdin", "источник данных: stdin, file, tcp")
+        sinkFlag   = flag.String("sink", "stdout", "приёмник данных: stdout, file, tcp")
+        sourcePath = flag.String("source-path", "in.txt", "путь к файлу для file-источника")
+        sinkPath   = flag.String("sink-path", "out.txt", "путь к файлу для file-приёмника")
+        sourceAddr = flag.String("source-addr", ":9000", "адрес для tcp-источника")
+        sinkAddr   = flag.String("sink-addr", ":9001", "адрес для tcp-приёмника")
+    )
+    flag.Parse()
+
+    source := newSource(*sourceFlag, *sourcePath, *sourceAddr)
+    sink := newSink(*sinkFlag, *sinkPath, *sinkAddr)
+
+    c, done := source.Start() // Источник данных и канал завершения работы
+
+    // Источники вроде TCPSource не останавливаются сами по себе (в отличие от
+    // ввода "exit" у StdinSource), поэтому по сигналу завершения останавливаем
+    // их явно через Close(); для остальных источников сигнал обрабатывается
+    // рантаймом по умолчанию
+    if closer, ok := source.(io.Closer); ok {
+        sigCh := make(chan os.Signal, 1)
+        signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+        go func() {
+            <-sigCh
+            closer.Close()
+        }()
     }
-    r.mu.Lock() // Блокировка мьютекса для обеспечения безопасности доступа к буферу
-    defer r.mu.Unlock() // Отложенное разблокирование мьютекса после выполнения функции
-    var output []int = r.array[:r.pos+1] // Создание слайса, который включает все элементы от начала буфера r.array до индекса r.pos включительно
-    r.pos = -1 // Виртуальная очистка нашего буфера, устанавливаем позицию в -1
 
-    return output // Возвращаем слайс с элементами буфера
-}
+    // Создаем пайплайн и передаем ему стадии
+    pipeline := NewPipeline[int](done,
+        negativeFilterStage, // Фильтрация отрицательных чисел
+        Parallel(specialFilterStage, runtime.NumCPU()), // Фильтрация чисел, кратных 3, исключая 0, параллельно на всех ядрах
+        bufferStage, // Буферизация данных
+    )
 
-// StageInt - Стадия конвейера, обрабатывающая целые числа
-type StageInt func(<-chan int, <-chan struct{}) <-chan int
+    // Дублируем результат конвейера: основной приёмник и потребитель метрик
+    // работают параллельно и не пересчитывают конвейер друг для друга
+    outs := pipeline.RunTee(c, 2)
 
-// Структура PipelineInt - Пайплайн обработки целых чисел
-type PipelineInt struct {
-    stages []StageInt // Список стадий конвейера
-    done   <-chan struct{} // Канал завершения работы
+    var wg sync.WaitGroup
+    wg.Add(2)
+    go func() {
+        defer wg.Done()
+        sink.Consume(done, outs[0])
+    }()
+    go func() {
+        defer wg.Done()
+        metricsConsumer(done, outs[1])
+    }()
+    wg.Wait()
 }
 
-// Функция NewPipelineInt - Создание пайплайна обработки целых чисел
-func NewPipelineInt(done <-chan struct{}, stages ...StageInt) *PipelineInt {
-    return &PipelineInt {
-        done: done,
-        stages: stages,
+// newSource - выбор реализации Source по значению флага -source
+func newSource(kind, path, addr string) Source {
+    switch kind {
+    case "file":
+        return NewFileSource(path)
+    case "tcp":
+        return NewTCPSource(addr)
+    default:
+        return StdinSource{}
     }
 }
 
-// Метод Run - Запуск пайплайна обработки целых чисел
-func (p *PipelineInt) Run(source <-chan int) <-chan int {
-    c := source // Инициализация канала для передачи данных
-    for _, stage := range p.stages {
-        c = stage(c, p.done) // Передача данных через каждую стадию
+// newSink - выбор реализации Sink по значению флага -sink
+func newSink(kind, path, addr string) Sink {
+    switch kind {
+    case "file":
+        return NewFileSink(path)
+    case "tcp":
+        return NewTCPSink(addr)
+    default:
+        return StdoutSink{}
     }
-
-    return c
-}
-
-func main() {
-    source, done := dataSource() // Источник данных и канал завершения работы
-
-    // Создаем пайплайн и передаем ему стадии
-    pipeline := NewPipelineInt(done,
-        negativeFilterStageInt, // Фильтрация отрицательных чисел
-        specialFilterStageInt, // Фильтрация чисел, кратных 3, исключая 0
-        bufferStageInt, // Буферизация данных
-    )
-
-    // Потребитель данных от пайплайна
-    consumer(done, pipeline.Run(source))
 }
 
 // Функция dataSource - Создание источника данных
@@ -130,135 +131,57 @@ func dataSource() (<-chan int, <-chan struct{}) {
     return c, done
 }
 
-// Функция negativeFilterStageInt - Стадия фильтрации отрицательных чисел
-func negativeFilterStageInt(input <-chan int, done <-chan struct{}) <-chan int {
-    output := make(chan int) // Канал для передачи отфильтрованных данных
-
-    go func() {
-        defer close(output)
-        for {
-            select {
-            case data, ok := <-input: // Чтение данных из входного канала
-                if !ok {
-
-                    return
-                }
-                if data >= 0 { // Если число не отрицательное
-                    select {
-                    case output <- data: // Отправка числа в выходной канал
-                    case <-done: // Проверка канала завершения работы
-
-                        return
-                    }
-                }
-            case <-done: // Если пришел сигнал завершения работы
-
-                return
-            }
-        }
-    }()
-
-    return output
+// negativeFilterStage - Стадия фильтрации отрицательных чисел, выраженная
+// через обобщенный Filter
+func negativeFilterStage(input <-chan int, done <-chan struct{}) <-chan int {
+    return Filter(done, input, func(data int) bool {
+        return data >= 0 // Оставляем только неотрицательные числа
+    })
 }
 
-// Функция specialFilterStageInt - Стадия фильтрации чисел, не кратных 3, исключая также и 0
-func specialFilterStageInt(input <-chan int, done <-chan struct{}) <-chan int {
-    output := make(chan int) // Канал для передачи отфильтрованных данных
-
-    go func() {
-        defer close(output)
-        for {
-            select {
-            case data, ok := <-input: // Чтение данных из входного канала
-                if !ok {
-
-                    return
-                }
-                if data != 0 && data%3 == 0 { // Если число кратно 3 и не равно 0
-                    select {
-                    case output <- data: // Отправка числа в выходной канал
-                    case <-done: // Проверка канала завершения работы
-                        
-                        return
-                    }
-                }
-            case <-done: // Если пришел сигнал завершения работы
-
-                return
-            }
-        }
-    }()
-
-    return output
+// specialFilterStage - Стадия фильтрации чисел, не кратных 3, исключая также и 0,
+// выраженная через обобщенный Filter
+func specialFilterStage(input <-chan int, done <-chan struct{}) <-chan int {
+    return Filter(done, input, func(data int) bool {
+        return data != 0 && data%3 == 0 // Число кратно 3 и не равно 0
+    })
 }
 
-// Функция bufferStageInt - Стадия буферизации данных
-func bufferStageInt(input <-chan int, done <-chan struct{}) <-chan int {
-    output := make(chan int) // Канал для передачи буферизованных данных
-    buffer := NewRingIntBuffer(bufferSize) // Кольцевой буфер
-
-    var wg sync.WaitGroup
-
-    wg.Add(1)
-    go func() {
-        defer wg.Done()
-        for {
-            select {
-            case data, ok := <-input: // Чтение данных из входного канала
-                if !ok {
+// bufferStage - Стадия буферизации данных, выраженная через обобщенный RingBuffer
+func bufferStage(input <-chan int, done <-chan struct{}) <-chan int {
+    return RingBuffer(done, input, bufferSize, bufferDrainInterval)
+}
 
-                    return
-                }
-                buffer.Push(data) // Добавление данных в буфер
-            case <-done: // Если пришел сигнал завершения работы
+// Функция consumer - Потребитель данных из канала
+func consumer(done <-chan struct{}, c <-chan int) {
+    for {
+        select {
+        case data, ok := <-c: // Чтение данных из канала
+            if !ok {
 
                 return
             }
-        }
-    }()
-    // В этой стадии есть вспомогательная горутина, выполняющая просмотр буфера с заданным интервалом времени - bufferDrainInterval
-    wg.Add(1)
-    go func() {
-        defer wg.Done()
-        for {
-            select {
-            case <-time.After(bufferDrainInterval): // Через заданный интервал времени
-                data := buffer.Get() // Получение данных из буфера
-                if data != nil {
-                    for _, v := range data {
-                        select {
-                        case output <- v: // Отправка элемента в выходной канал
-                        case <-done: // Проверка канала завершения работы
-
-                            return
-                        }
-                    }
-                }
-            case <-done: // Если пришел сигнал завершения работы
+            log.Printf("Обработаны данные: %d\n", data)
+        case <-done: // Если пришел сигнал завершения работы
 
-                return
-            }
+            return
         }
-    }()
-
-    go func() {
-        wg.Wait()
-        close(output)
-        }()
-
-        return output
+    }
 }
 
-// Функция consumer - Потребитель данных из канала
-func consumer(done <-chan struct{}, c <-chan int) {
+// metricsConsumer - Потребитель, считающий количество обработанных конвейером
+// значений, не мешая основному потребителю
+func metricsConsumer(done <-chan struct{}, c <-chan int) {
+    var count int
     for {
         select {
-        case data, ok := <-c: // Чтение данных из канала
+        case _, ok := <-c: // Чтение данных из канала
             if !ok {
+                log.Printf("Метрики: обработано значений: %d\n", count)
 
                 return
             }
-            log.Printf("Обработаны данные: %d\n", data)
+            count++
         case <-done: // Если пришел сигнал завершения работы
 
             return
@@ -0,0 +1,48 @@
+package main
+
+// Stage - стадия конвейера, обрабатывающая значения произвольного типа T.
+// Стадия владеет своим выходным каналом: она закрывает его, когда
+// закрывается входной канал input либо срабатывает done. Эта договорённость
+// (лексическое замыкание на output внутри стадии) сохраняется для всех
+// реализаций Stage в пакете.
+type Stage[T any] func(input <-chan T, done <-chan struct{}) <-chan T
+
+// Pipeline - обобщённый конвейер обработки данных произвольного типа T.
+type Pipeline[T any] struct {
+    stages []Stage[T] // Список стадий конвейера
+    done   <-chan struct{} // Канал завершения работы
+}
+
+// NewPipeline - создание конвейера из набора стадий.
+func NewPipeline[T any](done <-chan struct{}, stages ...Stage[T]) *Pipeline[T] {
+    return &Pipeline[T]{
+        done:   done,
+        stages: stages,
+    }
+}
+
+// Run - последовательный запуск всех стадий конвейера над source.
+func (p *Pipeline[T]) Run(source <-chan T) <-chan T {
+    c := source // Инициализация канала для передачи данных
+    for _, stage := range p.stages {
+        c = stage(c, p.done) // Передача данных через каждую стадию
+    }
+
+    return c
+}
+
+// RunTee - запускает конвейер и с помощью Broadcast дублирует результат
+// последней стадии в n независимых выходных каналов, так что, например,
+// основной потребитель и потребитель метрик могут работать параллельно
+// без повторного вычисления конвейера.
+func (p *Pipeline[T]) RunTee(source <-chan T, n int) []<-chan T {
+    return Broadcast(p.done, p.Run(source), n)
+}
+
+// RunDynamic - запускает конвейер поверх Bridge, разворачивающего поток
+// источников sources в единый канал. Это позволяет на лету подменять
+// источник данных (например, перейти со stdin на TCP-слушатель или на
+// воспроизведение из файла), не пересоздавая нижестоящие стадии конвейера.
+func (p *Pipeline[T]) RunDynamic(sources <-chan <-chan T) <-chan T {
+    return p.Run(Bridge(p.done, sources))
+}
@@ -0,0 +1,140 @@
+package main
+
+import "sync"
+
+// OverflowPolicy - поведение Buffer при попытке добавить элемент в
+// заполненный буфер.
+type OverflowPolicy int
+
+const (
+    // DropOldest - затирает самый старый элемент буфера. Это поведение
+    // классического кольцевого буфера и поведение по умолчанию.
+    DropOldest OverflowPolicy = iota
+    // DropNewest - отбрасывает добавляемый элемент, буфер остаётся без изменений.
+    DropNewest
+    // BlockUntilSpace - блокирует Push, пока в буфере не появится место.
+    BlockUntilSpace
+)
+
+// Buffer - ограниченный FIFO-буфер значений произвольного типа T с
+// настраиваемой политикой переполнения.
+type Buffer[T any] struct {
+    array    []T // Кольцевое хранилище элементов буфера
+    head     int // Индекс самого старого элемента (следующий для чтения)
+    tail     int // Индекс следующей свободной позиции для записи
+    length   int // Текущее количество элементов в буфере
+    size     int // Вместимость буфера
+    overflow OverflowPolicy // Политика поведения при заполненном буфере
+    mu       sync.Mutex // Мьютекс для обеспечения потокобезопасности
+    notFull  *sync.Cond // Условная переменная для BlockUntilSpace
+}
+
+// NewBuffer - создание нового FIFO-буфера заданного размера и политики
+// переполнения.
+func NewBuffer[T any](size int, overflow OverflowPolicy) *Buffer[T] {
+    b := &Buffer[T]{
+        array:    make([]T, size),
+        size:     size,
+        overflow: overflow,
+    }
+    b.notFull = sync.NewCond(&b.mu)
+
+    return b
+}
+
+// Push - добавление элемента в конец буфера согласно установленной
+// OverflowPolicy, если буфер заполнен. С BlockUntilSpace Push может
+// заблокироваться в ожидании места; done позволяет прервать это ожидание
+// при остановке конвейера - элемент в этом случае отбрасывается, как и при
+// DropNewest. Для остальных политик done не используется.
+func (b *Buffer[T]) Push(done <-chan struct{}, el T) {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+
+    if b.length == b.size {
+        switch b.overflow {
+        case DropNewest:
+
+            return
+        case BlockUntilSpace:
+            if !b.waitForSpace(done) {
+
+                return
+            }
+        default: // DropOldest
+            b.head = (b.head + 1) % b.size
+            b.length--
+        }
+    }
+
+    b.array[b.tail] = el // Запись элемента в конец буфера
+    b.tail = (b.tail + 1) % b.size
+    b.length++
+}
+
+// waitForSpace - ожидание освобождения места в буфере для BlockUntilSpace.
+// Вызывается с уже захваченным b.mu и возвращает его захваченным. Возвращает
+// false, если done сработал раньше, чем в буфере появилось место.
+func (b *Buffer[T]) waitForSpace(done <-chan struct{}) bool {
+    cancelled := make(chan struct{})
+    stopWatch := make(chan struct{})
+    defer close(stopWatch)
+
+    go func() {
+        select {
+        case <-done: // Сигнал остановки - будим Wait, чтобы Push мог вернуться
+            b.mu.Lock() // Под mu, чтобы пробуждение не потерялось между проверкой cancelled и Wait
+            close(cancelled)
+            b.notFull.Broadcast()
+            b.mu.Unlock()
+        case <-stopWatch: // Место появилось или ожидание уже прервано
+        }
+    }()
+
+    for {
+        select {
+        case <-cancelled:
+
+            return false
+        default:
+        }
+
+        if b.length != b.size {
+
+            return true
+        }
+
+        b.notFull.Wait()
+    }
+}
+
+// Len - количество элементов, находящихся в буфере в данный момент.
+func (b *Buffer[T]) Len() int {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+
+    return b.length
+}
+
+// Drain - атомарно возвращает все накопленные элементы в порядке их
+// добавления и опустошает буфер.
+func (b *Buffer[T]) Drain() []T {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+
+    if b.length == 0 {
+        return nil
+    }
+
+    output := make([]T, b.length)
+    for i := 0; i < b.length; i++ {
+        output[i] = b.array[(b.head+i)%b.size]
+    }
+
+    b.head = 0
+    b.tail = 0
+    b.length = 0
+    b.notFull.Broadcast() // Будим горутины, заблокированные в Push с BlockUntilSpace
+
+    return output
+}
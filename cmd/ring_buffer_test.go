@@ -0,0 +1,94 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+// TestBufferDropOldestPreservesFIFOOrderAfterWrap фиксирует поведение,
+// которое раньше ломал старый RingIntBuffer: после переполнения и
+// перехода хвоста через конец массива Drain должен вернуть оставшиеся
+// элементы в порядке добавления, а не содержимое внутреннего массива как
+// есть.
+func TestBufferDropOldestPreservesFIFOOrderAfterWrap(t *testing.T) {
+    b := NewBuffer[int](3, DropOldest)
+
+    for i := 1; i <= 5; i++ { // В буфер вместимостью 3 добавляются пять элементов подряд
+        b.Push(nil, i)
+    }
+
+    got := b.Drain()
+    want := []int{3, 4, 5} // Самые старые 1 и 2 должны были быть вытеснены
+    if len(got) != len(want) {
+        t.Fatalf("Drain() = %v, хотим %v", got, want)
+    }
+    for i := range want {
+        if got[i] != want[i] {
+            t.Fatalf("Drain() = %v, хотим %v", got, want)
+        }
+    }
+}
+
+// TestBufferDropNewestDiscardsOverflow проверяет, что с DropNewest
+// переполняющий элемент отбрасывается, а буфер не меняется.
+func TestBufferDropNewestDiscardsOverflow(t *testing.T) {
+    b := NewBuffer[int](2, DropNewest)
+
+    b.Push(nil, 1)
+    b.Push(nil, 2)
+    b.Push(nil, 3) // Буфер уже полон - должен быть отброшен
+
+    got := b.Drain()
+    want := []int{1, 2}
+    if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+        t.Fatalf("Drain() = %v, хотим %v", got, want)
+    }
+}
+
+// TestBufferDrainEmptiesAtomically проверяет, что после Drain буфер пуст и
+// Len() возвращает 0.
+func TestBufferDrainEmptiesAtomically(t *testing.T) {
+    b := NewBuffer[int](4, DropOldest)
+    b.Push(nil, 1)
+    b.Push(nil, 2)
+
+    b.Drain()
+
+    if got := b.Len(); got != 0 {
+        t.Fatalf("Len() после Drain() = %d, хотим 0", got)
+    }
+}
+
+// TestBufferBlockUntilSpaceUnblocksOnDone проверяет, что Push,
+// заблокированный в ожидании места при политике BlockUntilSpace, не висит
+// вечно, а прерывается, как только закрывается done.
+func TestBufferBlockUntilSpaceUnblocksOnDone(t *testing.T) {
+    b := NewBuffer[int](1, BlockUntilSpace)
+    b.Push(nil, 1) // Заполняем единственное место в буфере
+
+    done := make(chan struct{})
+    pushReturned := make(chan struct{})
+
+    go func() {
+        b.Push(done, 2) // Буфер полон - блокируется в ожидании места
+        close(pushReturned)
+    }()
+
+    select {
+    case <-pushReturned:
+        t.Fatal("Push вернулся до закрытия done - он не должен был дождаться места")
+    case <-time.After(50 * time.Millisecond):
+    }
+
+    close(done)
+
+    select {
+    case <-pushReturned:
+    case <-time.After(time.Second):
+        t.Fatal("Push не прервался после закрытия done")
+    }
+
+    if got := b.Len(); got != 1 {
+        t.Fatalf("Len() = %d, хотим 1 - элемент при отмене должен быть отброшен", got)
+    }
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+    "bufio"
+    "fmt"
+    "log"
+    "net"
+    "os"
+    "sync"
+)
+
+// Sink - абстрактный потребитель результатов конвейера.
+type Sink interface {
+    Consume(done <-chan struct{}, c <-chan int)
+}
+
+// StdoutSink - потребитель, логирующий полученные числа (прежнее поведение consumer).
+type StdoutSink struct{}
+
+// Consume - запуск StdoutSink
+func (StdoutSink) Consume(done <-chan struct{}, c <-chan int) {
+    consumer(done, c)
+}
+
+// FileSink - потребитель, дописывающий полученные числа построчно в файл.
+type FileSink struct {
+    Path string // Путь к файлу для записи
+}
+
+// NewFileSink - создание приёмника, пишущего числа в файл path
+func NewFileSink(path string) *FileSink {
+    return &FileSink{Path: path}
+}
+
+// Consume - запуск FileSink
+func (s *FileSink) Consume(done <-chan struct{}, c <-chan int) {
+    f, err := os.OpenFile(s.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+    if err != nil {
+        log.Printf("Не удалось открыть файл %q для записи: %v\n", s.Path, err)
+
+        return
+    }
+    defer f.Close()
+
+    w := bufio.NewWriter(f)
+    defer w.Flush()
+
+    for {
+        select {
+        case data, ok := <-c: // Чтение данных из канала
+            if !ok {
+
+                return
+            }
+            fmt.Fprintln(w, data)
+        case <-done: // Если пришел сигнал завершения работы
+
+            return
+        }
+    }
+}
+
+// tcpSinkQueueSize - вместимость очереди одного TCP-клиента TCPSink.
+const tcpSinkQueueSize = 64
+
+// tcpSinkConn - одно TCP-соединение TCPSink со своей очередью и горутиной-
+// писателем, чтобы один зависший или медленно читающий клиент блокировал
+// только свою очередь, а не рассылку остальным (тот же принцип "медленный
+// потребитель блокирует только свою ветку", что и в Tee/Broadcast).
+type tcpSinkConn struct {
+    conn  net.Conn
+    queue chan int // Очередь данных на отправку этому клиенту
+}
+
+// newTCPSinkConn - создание очереди соединения заданной вместимости
+func newTCPSinkConn(conn net.Conn) *tcpSinkConn {
+    return &tcpSinkConn{conn: conn, queue: make(chan int, tcpSinkQueueSize)}
+}
+
+// enqueue - постановка данных в очередь соединения. Если очередь заполнена
+// (клиент не успевает вычитывать свой TCP receive window), самый старый
+// элемент отбрасывается - та же политика DropOldest, что и у RingBuffer.
+func (sc *tcpSinkConn) enqueue(data int) {
+    for {
+        select {
+        case sc.queue <- data:
+
+            return
+        default:
+            select {
+            case <-sc.queue: // Отбрасываем самый старый ещё не отправленный элемент
+            default:
+            }
+        }
+    }
+}
+
+// writeLoop - горутина-писатель соединения: последовательно пишет данные
+// из queue в conn и вызывает drop, как только запись не удалась или queue
+// закрыта при остановке TCPSink.
+func (sc *tcpSinkConn) writeLoop(drop func()) {
+    defer drop()
+
+    for data := range sc.queue {
+        if _, err := fmt.Fprintln(sc.conn, data); err != nil {
+
+            return
+        }
+    }
+}
+
+// TCPSink - потребитель, рассылающий полученные числа всем подключенным
+// TCP-клиентам.
+type TCPSink struct {
+    Addr string // Адрес, на котором принимаются соединения клиентов
+
+    mu     sync.Mutex
+    conns  map[net.Conn]*tcpSinkConn
+    closed bool
+}
+
+// NewTCPSink - создание приёмника, рассылающего числа клиентам, подключенным к addr
+func NewTCPSink(addr string) *TCPSink {
+    return &TCPSink{Addr: addr}
+}
+
+// dropConn - закрытие соединения и удаление его из списка активных
+func (s *TCPSink) dropConn(conn net.Conn) {
+    s.mu.Lock()
+    delete(s.conns, conn)
+    s.mu.Unlock()
+
+    conn.Close()
+}
+
+// shutdown - остановка приёма новых соединений и закрытие всех уже
+// открытых, выполняется не более одного раза. Соединения закрываются и их
+// очереди - под тем же mu, которым enqueue ограждает рассылку, поэтому
+// ни одна enqueue не попадёт в уже закрытую очередь; closed проверяется
+// под тем же mu в accept-цикле, так что соединение, принятое после
+// shutdown, будет закрыто сразу же, а не останется висеть.
+func (s *TCPSink) shutdown(ln net.Listener) {
+    s.mu.Lock()
+    if s.closed {
+        s.mu.Unlock()
+
+        return
+    }
+    s.closed = true
+
+    for _, sc := range s.conns {
+        sc.conn.Close()
+        close(sc.queue)
+    }
+    s.conns = nil // Закрытые очереди больше не участвуют в рассылке
+    s.mu.Unlock()
+
+    ln.Close()
+}
+
+// Consume - запуск TCPSink
+func (s *TCPSink) Consume(done <-chan struct{}, c <-chan int) {
+    ln, err := net.Listen("tcp", s.Addr)
+    if err != nil {
+        log.Printf("Не удалось запустить TCP-приёмник на %q: %v\n", s.Addr, err)
+
+        return
+    }
+    defer s.shutdown(ln)
+
+    s.mu.Lock()
+    s.conns = make(map[net.Conn]*tcpSinkConn)
+    s.mu.Unlock()
+
+    go func() {
+        <-done
+        s.shutdown(ln)
+    }()
+
+    go func() {
+        for {
+            conn, err := ln.Accept()
+            if err != nil {
+
+                return
+            }
+
+            s.mu.Lock()
+            if s.closed { // shutdown уже закрыл слушателя и текущие соединения
+                s.mu.Unlock()
+                conn.Close()
+
+                continue
+            }
+            sc := newTCPSinkConn(conn)
+            s.conns[conn] = sc
+            s.mu.Unlock()
+
+            go sc.writeLoop(func() { s.dropConn(conn) })
+        }
+    }()
+
+    for {
+        select {
+        case data, ok := <-c: // Чтение данных из канала
+            if !ok {
+
+                return
+            }
+            s.mu.Lock()
+            for _, sc := range s.conns {
+                sc.enqueue(data)
+            }
+            s.mu.Unlock()
+        case <-done: // Если пришел сигнал завершения работы
+
+            return
+        }
+    }
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+    "io"
+    "net"
+    "testing"
+    "time"
+)
+
+// freeTCPAddr - резервирует свободный порт на loopback-интерфейсе и сразу
+// освобождает его, чтобы вернуть адрес, на который можно безопасно
+// запустить TCPSink.
+func freeTCPAddr(t *testing.T) string {
+    t.Helper()
+
+    ln, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatalf("не удалось зарезервировать порт: %v", err)
+    }
+    addr := ln.Addr().String()
+    ln.Close()
+
+    return addr
+}
+
+// TestTCPSinkSlowClientDoesNotBlockDrain проверяет, что зависший клиент,
+// не читающий из своего соединения, не блокирует рассылку остальным и не
+// останавливает вычитывание входного канала c - раньше запись всем
+// клиентам под общим mu в одном цикле приводила именно к этому.
+func TestTCPSinkSlowClientDoesNotBlockDrain(t *testing.T) {
+    sink := NewTCPSink(freeTCPAddr(t))
+
+    done := make(chan struct{})
+    c := make(chan int)
+
+    consumeDone := make(chan struct{})
+    go func() {
+        sink.Consume(done, c)
+        close(consumeDone)
+    }()
+
+    var conn net.Conn
+    var err error
+    for i := 0; i < 100; i++ {
+        conn, err = net.Dial("tcp", sink.Addr)
+        if err == nil {
+            break
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    if err != nil {
+        t.Fatalf("не удалось подключиться к TCPSink: %v", err)
+    }
+    defer conn.Close()
+
+    // Не читаем из conn - имитируем зависшего клиента, чьё TCP receive
+    // window рано или поздно заполнится.
+    const n = tcpSinkQueueSize * 4
+    for i := 0; i < n; i++ {
+        select {
+        case c <- i: // Отправка очередного значения на вычитывание TCPSink'ом
+        case <-time.After(time.Second):
+            t.Fatalf("Consume перестал вычитывать c после %d из %d значений - зависший клиент заблокировал рассылку", i, n)
+        }
+    }
+
+    close(done)
+
+    select {
+    case <-consumeDone:
+    case <-time.After(time.Second):
+        t.Fatal("Consume не завершился после закрытия done")
+    }
+
+    // Прежде чем закрыть conn, TCPSink мог успеть протолкнуть в него часть
+    // значений из очереди - поэтому дочитываем всё, что там накопилось, и
+    // только терминальную ошибку проверяем на io.EOF, а не самый первый байт.
+    buf := make([]byte, 4096)
+    conn.SetReadDeadline(time.Now().Add(time.Second))
+    for {
+        _, err = conn.Read(buf)
+        if err != nil {
+            break
+        }
+    }
+    if err != io.EOF {
+        t.Fatalf("ожидали закрытия соединения при остановке TCPSink, получили err=%v", err)
+    }
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+    "bufio"
+    "log"
+    "net"
+    "os"
+    "strconv"
+    "strings"
+    "sync"
+)
+
+// Source - абстрактный источник данных для конвейера: отдаёт канал
+// значений и канал done, сигнализирующий об остановке источника.
+type Source interface {
+    Start() (<-chan int, <-chan struct{})
+}
+
+// StdinSource - источник, построчно читающий целые числа из stdin
+// (прежнее поведение dataSource).
+type StdinSource struct{}
+
+// Start - запуск StdinSource
+func (StdinSource) Start() (<-chan int, <-chan struct{}) {
+    return dataSource()
+}
+
+// FileSource - источник, воспроизводящий целые числа из файла, по одному
+// числу на строку.
+type FileSource struct {
+    Path string // Путь к файлу с числами
+}
+
+// NewFileSource - создание источника, читающего числа из файла Path
+func NewFileSource(path string) *FileSource {
+    return &FileSource{Path: path}
+}
+
+// Start - запуск FileSource
+func (s *FileSource) Start() (<-chan int, <-chan struct{}) {
+    c := make(chan int) // Канал для передачи данных
+    done := make(chan struct{}) // Канал для завершения работы
+
+    go func() {
+        defer close(done)
+        defer close(c)
+
+        f, err := os.Open(s.Path)
+        if err != nil {
+            log.Printf("Не удалось открыть файл %q: %v\n", s.Path, err)
+
+            return
+        }
+        defer f.Close()
+
+        scanner := bufio.NewScanner(f)
+        for scanner.Scan() {
+            data := strings.TrimSpace(scanner.Text())
+            if data == "" {
+                continue
+            }
+
+            i, err := strconv.Atoi(data)
+            if err != nil {
+                log.Println("Ошибка чтения файла, строка пропущена: не целое число!")
+
+                continue
+            }
+
+            c <- i // Отправка целого числа в канал
+        }
+    }()
+
+    return c, done
+}
+
+// TCPSource - источник, принимающий TCP-соединения на Addr и
+// мультиплексирующий целые числа от всех подключенных клиентов в один канал:
+// каждое соединение читает своя горутина, а общий канал закрывается после
+// завершения их всех (по sync.WaitGroup, как в FanIn); сама функция FanIn
+// здесь не вызывается. TCPSource владеет своим слушателем и открытыми соединениями:
+// Close останавливает его так же, как ввод "exit" останавливает StdinSource.
+type TCPSource struct {
+    Addr string // Адрес, на котором принимаются соединения
+
+    mu     sync.Mutex
+    ln     net.Listener
+    conns  map[net.Conn]struct{}
+    closed bool
+}
+
+// NewTCPSource - создание источника, принимающего соединения на addr
+func NewTCPSource(addr string) *TCPSource {
+    return &TCPSource{Addr: addr}
+}
+
+// Start - запуск TCPSource
+func (s *TCPSource) Start() (<-chan int, <-chan struct{}) {
+    output := make(chan int) // Канал для передачи данных
+    done := make(chan struct{}) // Канал для завершения работы
+
+    ln, err := net.Listen("tcp", s.Addr)
+    if err != nil {
+        log.Printf("Не удалось запустить TCP-источник на %q: %v\n", s.Addr, err)
+        close(output)
+        close(done)
+
+        return output, done
+    }
+
+    s.mu.Lock()
+    s.ln = ln
+    s.conns = make(map[net.Conn]struct{})
+    s.mu.Unlock()
+
+    var wg sync.WaitGroup
+
+    go func() {
+        defer close(done) // Сигнализируем об остановке источника после закрытия слушателя, завершения всех соединений и закрытия output
+        defer func() {
+            wg.Wait() // Дожидаемся, пока отработают все соединения
+            close(output)
+        }()
+
+        for {
+            conn, err := ln.Accept()
+            if err != nil {
+                // Accept падает, когда Close() закрывает слушателя - завершаем работу
+
+                return
+            }
+
+            s.mu.Lock()
+            if s.closed { // Close() уже закрыл слушателя и текущие соединения
+                s.mu.Unlock()
+                conn.Close()
+
+                continue
+            }
+            s.conns[conn] = struct{}{}
+            s.mu.Unlock()
+
+            wg.Add(1)
+            go func(conn net.Conn) {
+                defer wg.Done()
+
+                // Читает это соединение и пересылает его числа в общий output,
+                // тем самым сливая потоки всех подключений в один канал - то
+                // же самое, что делает по одному входному каналу FanIn
+                for data := range s.readConn(done, conn) {
+                    select {
+                    case output <- data: // Отправка числа в общий выходной канал
+                    case <-done: // Если пришел сигнал завершения работы
+
+                        return
+                    }
+                }
+            }(conn)
+        }
+    }()
+
+    return output, done
+}
+
+// readConn - чтение целых чисел из одного TCP-соединения, по одному на строку
+func (s *TCPSource) readConn(done <-chan struct{}, conn net.Conn) <-chan int {
+    output := make(chan int) // Канал для передачи чисел этого соединения
+
+    go func() {
+        defer close(output)
+        defer s.dropConn(conn)
+
+        scanner := bufio.NewScanner(conn)
+        for scanner.Scan() {
+            data := strings.TrimSpace(scanner.Text())
+            if data == "" {
+                continue
+            }
+
+            i, err := strconv.Atoi(data)
+            if err != nil {
+                continue
+            }
+
+            select {
+            case output <- i: // Отправка числа в выходной канал соединения
+            case <-done: // Если пришел сигнал завершения работы
+
+                return
+            }
+        }
+    }()
+
+    return output
+}
+
+// dropConn - закрытие conn и удаление его из списка активных соединений
+func (s *TCPSource) dropConn(conn net.Conn) {
+    conn.Close()
+
+    s.mu.Lock()
+    delete(s.conns, conn)
+    s.mu.Unlock()
+}
+
+// Close - остановка TCPSource: закрывает слушателя и все открытые
+// соединения, из-за чего их читающие горутины завершаются без утечек, а
+// Start закрывает output и done.
+func (s *TCPSource) Close() error {
+    s.mu.Lock()
+    if s.closed {
+        s.mu.Unlock()
+
+        return nil
+    }
+    s.closed = true // Запрещаем accept-циклу регистрировать новые соединения
+
+    ln := s.ln
+    conns := make([]net.Conn, 0, len(s.conns))
+    for conn := range s.conns {
+        conns = append(conns, conn)
+    }
+    s.mu.Unlock()
+
+    if ln != nil {
+        ln.Close()
+    }
+    for _, conn := range conns {
+        conn.Close()
+    }
+
+    return nil
+}
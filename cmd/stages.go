@@ -0,0 +1,222 @@
+package main
+
+import (
+    "sync"
+    "time"
+)
+
+// Generator - источник значений конвейера: отправляет items по выходному
+// каналу одно за другим и закрывает его, как только они исчерпаны, либо
+// раньше, если сработал done.
+func Generator[T any](done <-chan struct{}, items ...T) <-chan T {
+    output := make(chan T) // Канал для передачи значений
+
+    go func() {
+        defer close(output)
+        for _, item := range items {
+            select {
+            case <-done: // done проверяется первым, чтобы после остановки не отправить ни одного значения
+
+                return
+            default:
+            }
+            select {
+            case output <- item: // Отправка очередного значения
+            case <-done: // Если пришел сигнал завершения работы
+
+                return
+            }
+        }
+    }()
+
+    return output
+}
+
+// Repeat - бесконечно вызывает fn и отправляет результат в выходной канал,
+// пока не придет done.
+func Repeat[T any](done <-chan struct{}, fn func() T) <-chan T {
+    output := make(chan T) // Канал для передачи значений
+
+    go func() {
+        defer close(output)
+        for {
+            select {
+            case output <- fn(): // Отправка результата очередного вызова fn
+            case <-done: // Если пришел сигнал завершения работы
+
+                return
+            }
+        }
+    }()
+
+    return output
+}
+
+// Take - пропускает через себя не более n значений из in, после чего
+// завершает работу и закрывает выходной канал.
+func Take[T any](done <-chan struct{}, in <-chan T, n int) <-chan T {
+    output := make(chan T) // Канал для передачи значений
+
+    go func() {
+        defer close(output)
+        for i := 0; i < n; i++ {
+            select {
+            case data, ok := <-in: // Чтение данных из входного канала
+                if !ok {
+
+                    return
+                }
+                select {
+                case output <- data: // Отправка значения в выходной канал
+                case <-done: // Если пришел сигнал завершения работы
+
+                    return
+                }
+            case <-done: // Если пришел сигнал завершения работы
+
+                return
+            }
+        }
+    }()
+
+    return output
+}
+
+// Map - применяет fn к каждому элементу in, преобразуя элементы типа S в
+// элементы типа T.
+func Map[S, T any](done <-chan struct{}, in <-chan S, fn func(S) T) <-chan T {
+    output := make(chan T) // Канал для передачи преобразованных значений
+
+    go func() {
+        defer close(output)
+        for {
+            select {
+            case data, ok := <-in: // Чтение данных из входного канала
+                if !ok {
+
+                    return
+                }
+                select {
+                case output <- fn(data): // Отправка преобразованного значения в выходной канал
+                case <-done: // Если пришел сигнал завершения работы
+
+                    return
+                }
+            case <-done: // Если пришел сигнал завершения работы
+
+                return
+            }
+        }
+    }()
+
+    return output
+}
+
+// Filter - пропускает через себя только те элементы in, для которых pred
+// возвращает true.
+func Filter[T any](done <-chan struct{}, in <-chan T, pred func(T) bool) <-chan T {
+    output := make(chan T) // Канал для передачи отфильтрованных данных
+
+    go func() {
+        defer close(output)
+        for {
+            select {
+            case data, ok := <-in: // Чтение данных из входного канала
+                if !ok {
+
+                    return
+                }
+                if pred(data) { // Если предикат выполняется
+                    select {
+                    case output <- data: // Отправка значения в выходной канал
+                    case <-done: // Если пришел сигнал завершения работы
+
+                        return
+                    }
+                }
+            case <-done: // Если пришел сигнал завершения работы
+
+                return
+            }
+        }
+    }()
+
+    return output
+}
+
+// RingBuffer - стадия буферизации данных: копит значения из in в буфере
+// размера size (с политикой DropOldest) и сбрасывает их в выходной канал
+// с интервалом interval. Когда input закрывается, остаток буфера
+// сбрасывается в output перед закрытием стадии.
+func RingBuffer[T any](done <-chan struct{}, in <-chan T, size int, interval time.Duration) <-chan T {
+    output := make(chan T) // Канал для передачи буферизованных данных
+    buffer := NewBuffer[T](size, DropOldest) // Буфер
+
+    // producerDone закрывается, как только исчерпан in, независимо от done -
+    // иначе горутина с тикером держится только на done и виснет навсегда,
+    // если стадия выше закрывает свой выход, не закрывая done (например, Take)
+    producerDone := make(chan struct{})
+
+    var wg sync.WaitGroup
+
+    wg.Add(1)
+    go func() {
+        defer wg.Done()
+        defer close(producerDone)
+        for {
+            select {
+            case data, ok := <-in: // Чтение данных из входного канала
+                if !ok {
+
+                    return
+                }
+                buffer.Push(done, data) // Добавление данных в буфер
+            case <-done: // Если пришел сигнал завершения работы
+
+                return
+            }
+        }
+    }()
+    // В этой стадии есть вспомогательная горутина, выполняющая просмотр буфера с заданным интервалом времени - interval
+    wg.Add(1)
+    go func() {
+        defer wg.Done()
+        ticker := time.NewTicker(interval)
+        defer ticker.Stop()
+
+        for {
+            select {
+            case <-ticker.C: // Через заданный интервал времени
+                if !drainBuffer(output, buffer, done) {
+                    return
+                }
+            case <-producerDone: // in исчерпан либо сработал done - дальше сбрасывать нечего
+
+                return
+            }
+        }
+    }()
+
+    go func() {
+        wg.Wait()
+        drainBuffer(output, buffer, done) // Сброс остатка буфера перед закрытием стадии
+        close(output)
+    }()
+
+    return output
+}
+
+// drainBuffer - вычитывает всё содержимое buffer и пересылает его в output
+// в порядке добавления. Возвращает false, если отправку прервал done.
+func drainBuffer[T any](output chan<- T, buffer *Buffer[T], done <-chan struct{}) bool {
+    for _, v := range buffer.Drain() {
+        select {
+        case output <- v: // Отправка элемента в выходной канал
+        case <-done: // Проверка канала завершения работы
+
+            return false
+        }
+    }
+
+    return true
+}
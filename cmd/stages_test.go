@@ -0,0 +1,125 @@
+package main
+
+import (
+    "reflect"
+    "testing"
+    "time"
+)
+
+// drain вычитывает canал c до закрытия и возвращает все полученные значения.
+func drain[T any](c <-chan T) []T {
+    var got []T
+    for v := range c {
+        got = append(got, v)
+    }
+
+    return got
+}
+
+// TestGeneratorEmitsItemsInOrder проверяет, что Generator отправляет items
+// по порядку и закрывает выходной канал, как только они исчерпаны.
+func TestGeneratorEmitsItemsInOrder(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    got := drain(Generator(done, 1, 2, 3))
+    want := []int{1, 2, 3}
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("Generator() = %v, хотим %v", got, want)
+    }
+}
+
+// TestGeneratorStopsOnDone проверяет, что Generator завершает работу и
+// закрывает выходной канал, как только срабатывает done, даже не отправив
+// все items.
+func TestGeneratorStopsOnDone(t *testing.T) {
+    done := make(chan struct{})
+    close(done)
+
+    output := Generator(done, 1, 2, 3)
+
+    select {
+    case _, ok := <-output:
+        if ok {
+            t.Fatal("ожидали закрытый канал сразу после done, получили значение")
+        }
+    case <-time.After(time.Second):
+        t.Fatal("Generator не закрыл выходной канал после done")
+    }
+}
+
+// TestTakeLimitsToN проверяет, что Take пропускает не более n значений из
+// бесконечного in и после этого закрывает выходной канал.
+func TestTakeLimitsToN(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    i := 0
+    in := Repeat(done, func() int {
+        i++
+
+        return i
+    })
+
+    got := drain(Take(done, in, 3))
+    want := []int{1, 2, 3}
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("Take() = %v, хотим %v", got, want)
+    }
+}
+
+// TestMapTransformsEachElement проверяет, что Map применяет fn к каждому
+// элементу, преобразуя S в T.
+func TestMapTransformsEachElement(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    in := Generator(done, 1, 2, 3)
+    got := drain(Map(done, in, func(i int) string {
+        if i%2 == 0 {
+            return "even"
+        }
+
+        return "odd"
+    }))
+    want := []string{"odd", "even", "odd"}
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("Map() = %v, хотим %v", got, want)
+    }
+}
+
+// TestFilterKeepsOnlyMatching проверяет, что Filter пропускает только
+// элементы, для которых pred вернул true.
+func TestFilterKeepsOnlyMatching(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    in := Generator(done, -2, -1, 0, 1, 2)
+    got := drain(Filter(done, in, func(i int) bool { return i >= 0 }))
+    want := []int{0, 1, 2}
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("Filter() = %v, хотим %v", got, want)
+    }
+}
+
+// TestPipelineRunComposesStagesInOrder проверяет, что Pipeline.Run
+// последовательно пропускает source через все стадии в порядке их
+// добавления.
+func TestPipelineRunComposesStagesInOrder(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    negativeFilter := func(in <-chan int, done <-chan struct{}) <-chan int {
+        return Filter(done, in, func(i int) bool { return i >= 0 })
+    }
+    double := func(in <-chan int, done <-chan struct{}) <-chan int {
+        return Map(done, in, func(i int) int { return i * 2 })
+    }
+
+    pipeline := NewPipeline[int](done, negativeFilter, double)
+    got := drain(pipeline.Run(Generator(done, -1, 1, 2, 3)))
+    want := []int{2, 4, 6}
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("Pipeline.Run() = %v, хотим %v", got, want)
+    }
+}
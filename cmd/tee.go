@@ -0,0 +1,117 @@
+package main
+
+import "reflect"
+
+// Tee - дублирует каждое значение из in в два независимых выходных канала.
+// На каждой итерации значение считывается один раз, после чего вложенный
+// select пытается отправить его в оба выхода: как только отправка в один из
+// них удалась, соответствующая локальная переменная обнуляется, так что
+// медленный потребитель блокирует только свою ветку, не задерживая другую.
+func Tee[T any](done <-chan struct{}, in <-chan T) (<-chan T, <-chan T) {
+    out1 := make(chan T)
+    out2 := make(chan T)
+
+    go func() {
+        defer close(out1)
+        defer close(out2)
+
+        for {
+            var data T
+            var ok bool
+            select {
+            case data, ok = <-in: // Чтение очередного значения из входного канала
+                if !ok {
+
+                    return
+                }
+            case <-done: // Если пришел сигнал завершения работы
+
+                return
+            }
+
+            out1, out2 := out1, out2 // Локальные копии, обнуляемые после успешной отправки
+            for i := 0; i < 2; i++ {
+                select {
+                case out1 <- data:
+                    out1 = nil
+                case out2 <- data:
+                    out2 = nil
+                case <-done: // Если пришел сигнал завершения работы
+
+                    return
+                }
+            }
+        }
+    }()
+
+    return out1, out2
+}
+
+// Broadcast - обобщение Tee на произвольное число n потребителей: каждое
+// значение из in доставляется во все n выходных каналов. Поскольку число
+// каналов динамическое, для select по ещё не получившим значение веткам
+// используется reflect.Select.
+func Broadcast[T any](done <-chan struct{}, in <-chan T, n int) []<-chan T {
+    outputs := make([]chan T, n)
+    result := make([]<-chan T, n)
+    for i := range outputs {
+        outputs[i] = make(chan T)
+        result[i] = outputs[i]
+    }
+
+    go func() {
+        defer func() {
+            for _, out := range outputs {
+                close(out)
+            }
+        }()
+
+        for {
+            var data T
+            var ok bool
+            select {
+            case data, ok = <-in: // Чтение очередного значения из входного канала
+                if !ok {
+
+                    return
+                }
+            case <-done: // Если пришел сигнал завершения работы
+
+                return
+            }
+
+            pending := append([]chan T(nil), outputs...) // Каналы, ещё не получившие это значение
+            remaining := n
+
+            for remaining > 0 {
+                cases := make([]reflect.SelectCase, 0, remaining+1)
+                idx := make([]int, 0, remaining)
+                for i, out := range pending {
+                    if out == nil {
+                        continue
+                    }
+                    cases = append(cases, reflect.SelectCase{
+                        Dir:  reflect.SelectSend,
+                        Chan: reflect.ValueOf(out),
+                        Send: reflect.ValueOf(data),
+                    })
+                    idx = append(idx, i)
+                }
+                cases = append(cases, reflect.SelectCase{
+                    Dir:  reflect.SelectRecv,
+                    Chan: reflect.ValueOf(done),
+                })
+
+                chosen, _, _ := reflect.Select(cases)
+                if chosen == len(cases)-1 { // Сработал done
+
+                    return
+                }
+                pending[idx[chosen]] = nil // Эта ветка получила значение, исключаем её из ожидания
+                remaining--
+            }
+        }
+    }()
+
+    return result
+}
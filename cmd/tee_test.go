@@ -0,0 +1,123 @@
+package main
+
+import (
+    "reflect"
+    "sync"
+    "testing"
+    "time"
+)
+
+// TestTeeDuplicatesToBothOutputs проверяет, что Tee доставляет каждое
+// значение из in в оба выходных канала в неизменном порядке.
+func TestTeeDuplicatesToBothOutputs(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    in := Generator(done, 1, 2, 3)
+    out1, out2 := Tee(done, in)
+
+    var wg sync.WaitGroup
+    var got1, got2 []int
+    wg.Add(2)
+    go func() { defer wg.Done(); got1 = drain(out1) }()
+    go func() { defer wg.Done(); got2 = drain(out2) }()
+    wg.Wait()
+
+    want := []int{1, 2, 3}
+    if !reflect.DeepEqual(got1, want) {
+        t.Fatalf("out1 = %v, хотим %v", got1, want)
+    }
+    if !reflect.DeepEqual(got2, want) {
+        t.Fatalf("out2 = %v, хотим %v", got2, want)
+    }
+}
+
+// TestTeeOneBranchDoesNotWaitForTheOther проверяет главную гарантию Tee:
+// получение значения одной веткой не ждёт, пока его получит другая - если
+// бы это было не так, out1 завис бы, пока out2 никто не читает.
+func TestTeeOneBranchDoesNotWaitForTheOther(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    in := Generator(done, 42)
+    out1, out2 := Tee(done, in)
+
+    select {
+    case v := <-out1: // out2 в этот момент ещё никто не читает
+        if v != 42 {
+            t.Fatalf("out1 = %d, хотим 42", v)
+        }
+    case <-time.After(time.Second):
+        t.Fatal("out1 не получил значение, пока out2 не читали - одна ветка ждёт другую")
+    }
+
+    select {
+    case v := <-out2:
+        if v != 42 {
+            t.Fatalf("out2 = %d, хотим 42", v)
+        }
+    case <-time.After(time.Second):
+        t.Fatal("out2 не получил значение")
+    }
+}
+
+// TestBroadcastDuplicatesToAllOutputs проверяет обобщение Tee на n веток:
+// каждое значение из in доставляется во все выходные каналы.
+func TestBroadcastDuplicatesToAllOutputs(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    in := Generator(done, 1, 2, 3)
+    outputs := Broadcast(done, in, 3)
+
+    var wg sync.WaitGroup
+    got := make([][]int, len(outputs))
+    for i, out := range outputs {
+        wg.Add(1)
+        go func(i int, out <-chan int) {
+            defer wg.Done()
+            got[i] = drain(out)
+        }(i, out)
+    }
+    wg.Wait()
+
+    want := []int{1, 2, 3}
+    for i, g := range got {
+        if !reflect.DeepEqual(g, want) {
+            t.Fatalf("output %d = %v, хотим %v", i, g, want)
+        }
+    }
+}
+
+// TestPipelineRunTeeDuplicatesResult проверяет, что RunTee прогоняет
+// источник через стадии конвейера один раз и дублирует итог в n
+// независимых каналов.
+func TestPipelineRunTeeDuplicatesResult(t *testing.T) {
+    done := make(chan struct{})
+    defer close(done)
+
+    double := func(in <-chan int, done <-chan struct{}) <-chan int {
+        return Map(done, in, func(i int) int { return i * 2 })
+    }
+    pipeline := NewPipeline[int](done, double)
+
+    outputs := pipeline.RunTee(Generator(done, 1, 2, 3), 2)
+
+    var wg sync.WaitGroup
+    got := make([][]int, len(outputs))
+    for i, out := range outputs {
+        wg.Add(1)
+        go func(i int, out <-chan int) {
+            defer wg.Done()
+            got[i] = drain(out)
+        }(i, out)
+    }
+    wg.Wait()
+
+    want := []int{2, 4, 6}
+    for i, g := range got {
+        if !reflect.DeepEqual(g, want) {
+            t.Fatalf("output %d = %v, хотим %v", i, g, want)
+        }
+    }
+}